@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // Bulk package descriptor
@@ -25,11 +27,14 @@ const (
 	MySQLDB DatabaseType = iota + 1
 	// OracleDB database is Oracle
 	OracleDB
+	// PostgresDB database is PostgreSQL
+	PostgresDB
 )
 
 // one statement can not have more placeholders, thus they are limited
 const mysqlMaxPlaceholders = 65535
 const oracleMaxPlaceholders = 1000
+const postgresMaxPlaceholders = 65535
 
 // New init bulk insert
 // define database type (MySQL or Oracle) and provide database connection to init bulk insert
@@ -61,6 +66,14 @@ func (t *Bulk) BulkInsert(ctx context.Context, query string, rows []interface{})
 		return err
 	}
 
+	if t.dbType == PostgresDB {
+		copied, err := t.bulkInsertPostgresCopy(ctx, query, rows)
+		if copied {
+			return err
+		}
+		// driver doesn't support the COPY FROM STDIN protocol, fall back to a multi-values INSERT below
+	}
+
 	chunks := chunkIt(rows, t.dbType)
 	insertData := make([]queryArgs, len(chunks))
 
@@ -72,6 +85,13 @@ func (t *Bulk) BulkInsert(ctx context.Context, query string, rows []interface{})
 				return err
 			}
 		}
+	case PostgresDB:
+		for i := range chunks {
+			insertData[i].query, insertData[i].args, err = createStatementPostgres(query, chunks[i])
+			if err != nil {
+				return err
+			}
+		}
 	default: // MySQLDB
 		for i := range chunks {
 			insertData[i].query, insertData[i].args, err = createStatementMySQL(query, chunks[i])
@@ -81,6 +101,11 @@ func (t *Bulk) BulkInsert(ctx context.Context, query string, rows []interface{})
 		}
 	}
 
+	return t.execInsertData(insertData)
+}
+
+// execInsertData runs every generated statement inside a single transaction
+func (t *Bulk) execInsertData(insertData []queryArgs) error {
 	if t.conn == nil {
 		return errors.New("DB Connection is nil")
 	}
@@ -90,8 +115,28 @@ func (t *Bulk) BulkInsert(ctx context.Context, query string, rows []interface{})
 		if err != nil {
 			return errors.New("Error staring transaction")
 		}
+
+		// full-size chunks share the exact same generated statement, so
+		// prepare it once per distinct query text and reuse it
+		stmtCache := make(map[string]*sql.Stmt)
+		defer func() {
+			for _, stmt := range stmtCache {
+				stmt.Close()
+			}
+		}()
+
 		for i := range insertData {
-			_, err = tx.Exec(insertData[i].query, insertData[i].args...)
+			stmt, ok := stmtCache[insertData[i].query]
+			if !ok {
+				stmt, err = tx.Prepare(insertData[i].query)
+				if err != nil {
+					tx.Rollback()
+					return err
+				}
+				stmtCache[insertData[i].query] = stmt
+			}
+
+			_, err = stmt.Exec(insertData[i].args...)
 			if err != nil {
 				tx.Rollback()
 				return err
@@ -108,21 +153,24 @@ func (t *Bulk) BulkInsert(ctx context.Context, query string, rows []interface{})
 
 // CreateStatementMySQL create bulk insert statement for MySQL database based on provided arguments
 func createStatementMySQL(query string, rows []interface{}) (string, []interface{}, error) {
-	var err error
-	placeholder, count, err := createPlaceholder(query, rows[0])
+	placeholder, bindings, err := createPlaceholder(query, rows[0])
 	if err != nil {
 		return "", nil, err
 	}
 
 	placeholders := make([]string, len(rows))
-	args := make([]interface{}, (len(rows) * count))
+	args := make([]interface{}, len(rows)*len(bindings))
 
 	var argCount int
 	for i, entry := range rows {
 		placeholders[i] = placeholder
 		v := reflect.ValueOf(entry)
-		for y := 0; y < v.NumField(); y++ {
-			args[argCount] = v.Field(y).Interface()
+		for _, b := range bindings {
+			val, err := bindValue(v, b)
+			if err != nil {
+				return "", nil, err
+			}
+			args[argCount] = val
 			argCount++
 		}
 	}
@@ -134,10 +182,11 @@ func createStatementMySQL(query string, rows []interface{}) (string, []interface
 
 // CreateStatementOracle create bulk insert statement for Oracle database based on provided arguments
 func createStatementOracle(query string, rows []interface{}) (string, []interface{}, error) {
-	placeholder, lenCols, err := createPlaceholder(query, rows[0])
+	placeholder, bindings, err := createPlaceholder(query, rows[0])
 	if err != nil {
 		return "", nil, err
 	}
+	lenCols := len(bindings)
 	s := reflect.ValueOf(rows)
 	lenRows := s.Len()
 
@@ -157,16 +206,17 @@ func createStatementOracle(query string, rows []interface{}) (string, []interfac
 	for i := range rows {
 		queries[i] = fmt.Sprintf("%s %s", query, placeholder)
 		v := reflect.ValueOf(rows[i])
-		for y := 0; y < v.NumField(); y++ {
-			args[argCount] = v.Field(y).Interface()
+		for _, b := range bindings {
+			val, err := bindValue(v, b)
+			if err != nil {
+				return "", nil, err
+			}
+			args[argCount] = val
 			argCount++
 		}
 	}
 
-	mergedQuery := strings.Join(queries, " ")
-	for i := 0; i < lenRows*lenCols; i++ {
-		mergedQuery = strings.Replace(mergedQuery, "?", fmt.Sprintf(":%d", i), 1)
-	}
+	mergedQuery := rewriteOraclePlaceholders(strings.Join(queries, " "), lenRows*lenCols)
 
 	statement := wrapQuery(mergedQuery)
 	statement = removeDoubleSpace(statement)
@@ -174,11 +224,32 @@ func createStatementOracle(query string, rows []interface{}) (string, []interfac
 	return statement, args, nil
 }
 
+// rewriteOraclePlaceholders rewrites every "?" in query into sequential
+// Oracle bind names ":0", ":1", ... in a single pass, instead of repeatedly
+// scanning and rebuilding the string for each placeholder
+func rewriteOraclePlaceholders(query string, count int) string {
+	var b strings.Builder
+	b.Grow(len(query) + count*2)
+
+	n := 0
+	for i := 0; i < len(query); i++ {
+		if query[i] == '?' {
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(n))
+			n++
+		} else {
+			b.WriteByte(query[i])
+		}
+	}
+
+	return b.String()
+}
+
 // checkInsertRequest does various tests for insert statement and provided slice of data
 func (t *Bulk) checkInsertRequest(query string, rows []interface{}) error {
 
 	switch t.dbType {
-	case MySQLDB, OracleDB:
+	case MySQLDB, OracleDB, PostgresDB:
 	default:
 		return errors.New("Database type not defined")
 	}
@@ -215,6 +286,8 @@ func chunkIt(rows []interface{}, dbType DatabaseType) [][]interface{} {
 	switch dbType {
 	case OracleDB:
 		maxBatch = oracleMaxPlaceholders / fCount
+	case PostgresDB:
+		maxBatch = postgresMaxPlaceholders / fCount
 	default: // MySQLDB
 		maxBatch = mysqlMaxPlaceholders / fCount
 	}
@@ -256,6 +329,22 @@ func findBatchSize(a int, limit int) int {
 	return result
 }
 
+// subtractColumns returns the entries of base that are not present in exclude
+func subtractColumns(base []string, exclude []string) []string {
+	excluded := make(map[string]bool, len(exclude))
+	for _, c := range exclude {
+		excluded[c] = true
+	}
+
+	var result []string
+	for _, c := range base {
+		if !excluded[c] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
 // removeDoubleSpace remove double whitespace from query string
 func removeDoubleSpace(a string) string {
 	return strings.Replace(a, "  ", " ", -1)
@@ -272,47 +361,63 @@ func queryValuesStrip(query string) string {
 	return query
 }
 
-// createPlaceholder create placeholder for one insert on structure. Check if placeholder matches query column count. Returns placeholder, column count, error
-func createPlaceholder(query string, a interface{}) (string, int, error) {
+// statementMeta holds the per-(struct type, query) metadata that would
+// otherwise be recomputed by reflection and query parsing on every call
+type statementMeta struct {
+	bindings    []columnBinding
+	placeholder string
+}
+
+// metaCacheKey identifies a cached statementMeta
+type metaCacheKey struct {
+	t     reflect.Type
+	query string
+}
+
+// metaCache caches statementMeta across BulkInsert calls so repeated calls
+// with the same row type and query skip re-parsing and re-reflecting
+var metaCache sync.Map // map[metaCacheKey]statementMeta
+
+// createPlaceholder create placeholder for one insert on structure, matching
+// query's columns to a's fields (by bulk/db tag if present, otherwise by
+// declaration order). Returns placeholder, the resolved column bindings, error
+func createPlaceholder(query string, a interface{}) (string, []columnBinding, error) {
 	instance := reflect.TypeOf(a)
-	fCount := instance.NumField()
+	key := metaCacheKey{t: instance, query: query}
+
+	if cached, ok := metaCache.Load(key); ok {
+		meta := cached.(statementMeta)
+		return meta.placeholder, meta.bindings, nil
+	}
 
 	columns, err := extractQueryColumns(query)
 	if err != nil {
-		return "", 0, err
+		return "", nil, err
 	}
 
-	if len(columns) != fCount {
-		return "", 0, fmt.Errorf("Structure type doesn't match column count")
+	bindings, err := resolveColumnBindings(instance, columns)
+	if err != nil {
+		return "", nil, err
 	}
 
-	ph := make([]string, fCount)
-	for i := 0; i < fCount; i++ {
+	ph := make([]string, len(bindings))
+	for i := range ph {
 		ph[i] = "?"
 	}
 
 	placeholder := fmt.Sprintf("(%s)", strings.Join(ph, ","))
 
-	return placeholder, fCount, nil
+	metaCache.Store(key, statementMeta{bindings: bindings, placeholder: placeholder})
+
+	return placeholder, bindings, nil
 }
 
+// extractQueryColumns returns the column list of an INSERT INTO query,
+// parsed with the same tokenizer that backs extractTableName
 func extractQueryColumns(query string) ([]string, error) {
-	columnsStart := strings.Index(query, "(")
-	columnsEnd := strings.Index(query, ")")
-
-	if columnsStart < 0 || columnsEnd < 0 {
-		return nil, fmt.Errorf("Query columns not properly defined. Query: %s", query)
-	}
-
-	columnsString := query[columnsStart+1 : columnsEnd]
-	columnsString = strings.Replace(columnsString, " ", "", -1)
-	columns := strings.Split(columnsString, ",")
-
-	for i := range columns {
-		if columns[i] == "?" {
-			return nil, fmt.Errorf("Invalid column name: %s", columns[i])
-		}
+	_, columns, err := parseInsert(query)
+	if err != nil {
+		return nil, err
 	}
-
 	return columns, nil
 }
@@ -0,0 +1,39 @@
+package bulk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRewriteOraclePlaceholders(t *testing.T) {
+	got := rewriteOraclePlaceholders("insert all into service values (?,?) select * from dual", 2)
+	want := "insert all into service values (:0,:1) select * from dual"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCreatePlaceholderCache(t *testing.T) {
+	row := TestService{Description: "Desc 1", Tag: "Tag 1"}
+
+	placeholder, bindings, err := createPlaceholder(insertService, row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bindings) != 2 {
+		t.Errorf("expected 2 bindings, got %d", len(bindings))
+	}
+
+	key := metaCacheKey{t: reflect.TypeOf(row), query: insertService}
+	if _, ok := metaCache.Load(key); !ok {
+		t.Error("expected metadata to be cached after first call")
+	}
+
+	placeholder2, bindings2, err := createPlaceholder(insertService, row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placeholder != placeholder2 || len(bindings) != len(bindings2) {
+		t.Error("expected cached call to return identical result")
+	}
+}
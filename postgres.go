@@ -0,0 +1,139 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// bulkInsertPostgresCopy attempts the COPY FROM STDIN fast path for Postgres,
+// which is dramatically faster than a multi-values INSERT for large batches.
+//
+// It returns copied=true when err is the final result and should be returned
+// as-is: a row type that can't be bound to the query's columns, or the
+// outcome of the COPY itself. It returns copied=false when the fast path
+// wasn't taken (nil connection, a query it can't parse, a failed BEGIN, or a
+// driver that doesn't recognize the pq-style CopyIn query), in which case the
+// caller should fall back to a multi-values INSERT built by
+// createStatementPostgres instead.
+func (t *Bulk) bulkInsertPostgresCopy(ctx context.Context, query string, rows []interface{}) (bool, error) {
+	if t.conn == nil {
+		return false, nil
+	}
+
+	table, columns, err := extractTableAndColumns(query)
+	if err != nil {
+		return false, nil
+	}
+
+	tx, err := t.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	bindings, err := resolveColumnBindings(reflect.TypeOf(rows[0]), columns)
+	if err != nil {
+		tx.Rollback()
+		return true, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, copyInStatement(table, columns))
+	if err != nil {
+		// driver doesn't understand the COPY magic query, it isn't pq/pgx
+		tx.Rollback()
+		return false, nil
+	}
+
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		args := make([]interface{}, len(bindings))
+		for y, b := range bindings {
+			val, err := bindValue(v, b)
+			if err != nil {
+				stmt.Close()
+				tx.Rollback()
+				return true, err
+			}
+			args[y] = val
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			stmt.Close()
+			tx.Rollback()
+			return true, err
+		}
+	}
+
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		tx.Rollback()
+		return true, err
+	}
+	if err := stmt.Close(); err != nil {
+		tx.Rollback()
+		return true, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// copyInStatement builds the "COPY table (cols...) FROM STDIN" query that
+// the lib/pq and pgx stdlib drivers recognize as the start of the COPY protocol
+func copyInStatement(table string, columns []string) string {
+	quotedCols := make([]string, len(columns))
+	for i, c := range columns {
+		quotedCols[i] = fmt.Sprintf("%q", c)
+	}
+	return fmt.Sprintf("COPY %s (%s) FROM STDIN", table, strings.Join(quotedCols, ","))
+}
+
+// extractTableAndColumns pulls the target table and column list out of an
+// INSERT INTO query template
+func extractTableAndColumns(query string) (string, []string, error) {
+	table, err := extractTableName(query)
+	if err != nil {
+		return "", nil, err
+	}
+	columns, err := extractQueryColumns(query)
+	if err != nil {
+		return "", nil, err
+	}
+	return table, columns, nil
+}
+
+// createStatementPostgres create bulk insert statement for Postgres database
+// based on provided arguments; used as the multi-values INSERT fallback when
+// the driver doesn't support the COPY FROM STDIN fast path
+func createStatementPostgres(query string, rows []interface{}) (string, []interface{}, error) {
+	_, bindings, err := createPlaceholder(query, rows[0])
+	if err != nil {
+		return "", nil, err
+	}
+
+	placeholders := make([]string, len(rows))
+	args := make([]interface{}, len(rows)*len(bindings))
+
+	var argCount int
+	for i, entry := range rows {
+		ph := make([]string, len(bindings))
+		v := reflect.ValueOf(entry)
+		for y, b := range bindings {
+			argCount++
+			ph[y] = fmt.Sprintf("$%d", argCount)
+			val, err := bindValue(v, b)
+			if err != nil {
+				return "", nil, err
+			}
+			args[argCount-1] = val
+		}
+		placeholders[i] = fmt.Sprintf("(%s)", strings.Join(ph, ","))
+	}
+
+	query = queryValuesStrip(query)
+	statement := fmt.Sprintf("%s %s", query, strings.Join(placeholders, ","))
+	return statement, args, nil
+}
@@ -0,0 +1,57 @@
+package bulk
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+func TestBulkInsertPostgres(t *testing.T) {
+	var conn *sql.DB
+	ctx := context.Background()
+
+	b, err := New(PostgresDB, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []interface{}{
+		TestService{Description: "Desc 1", Tag: "Tag 1"},
+		TestService{Description: "Desc 2", Tag: "Tag 2"},
+	}
+
+	err = b.BulkInsert(ctx, insertService, rows)
+	fmt.Println("Expected error: ", err)
+	if err == nil {
+		t.Error("expected error for nil connection")
+	}
+}
+
+func TestCreateStatementPostgres(t *testing.T) {
+	rows := []interface{}{
+		TestService{Description: "Desc 1", Tag: "Tag 1"},
+		TestService{Description: "Desc 2", Tag: "Tag 2"},
+	}
+
+	statement, args, err := createStatementPostgres(insertService, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 4 {
+		t.Errorf("expected 4 args, got %d", len(args))
+	}
+
+	expected := "insert into service (description, tag)  values ($1,$2),($3,$4)"
+	if statement != expected {
+		t.Errorf("expected %q, got %q", expected, statement)
+	}
+}
+
+func TestCopyInStatement(t *testing.T) {
+	got := copyInStatement("service", []string{"description", "tag"})
+	expected := `COPY service ("description","tag") FROM STDIN`
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,275 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultMaxConcurrency = 4
+	defaultMaxBatchSize   = 1000
+	defaultMaxAttempts    = 3
+	defaultBaseDelay      = 100 * time.Millisecond
+	defaultMaxDelay       = 5 * time.Second
+	defaultFlushInterval  = 200 * time.Millisecond
+)
+
+// transient error markers that BulkInsertStream will retry a batch for
+var retryableErrorMarkers = []string{
+	"1213",          // MySQL: deadlock found when trying to get lock
+	"1205",          // MySQL: lock wait timeout exceeded
+	"connection reset",
+	"broken pipe",
+	"ORA-00060", // Oracle: deadlock detected while waiting for resource
+	"ORA-04068", // Oracle: existing state of packages has been discarded
+}
+
+// RetryPolicy controls how BulkInsertStream retries a batch that failed with
+// a transient error. Zero values fall back to sane defaults.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// ProgressFunc is invoked once per batch, after the batch has finished
+// (successfully or not, including any retries), with the size of that batch,
+// the running total of rows processed so far, and the batch's final error,
+// if any.
+type ProgressFunc func(batchRows int, totalRows int, err error)
+
+// StreamOptions configures BulkInsertStream
+type StreamOptions struct {
+	// MaxConcurrency bounds how many batches are in flight at once
+	MaxConcurrency int
+	// MaxBatchSize bounds how many rows go into a single statement; it is
+	// further capped by the dialect's placeholder limit
+	MaxBatchSize int
+	// MinBatchSize keeps small tail batches from being flushed on the
+	// periodic timer before enough rows have accumulated
+	MinBatchSize int
+	RetryPolicy  RetryPolicy
+	OnProgress   ProgressFunc
+}
+
+// BulkInsertStream consumes rows from a channel, batches them up to the
+// dialect placeholder limit (capped further by MaxBatchSize), and dispatches
+// batches to a bounded worker pool, retrying transient errors with
+// exponential backoff. It returns once rows is drained and every dispatched
+// batch has finished. When a batch fails (with a non-retryable error, or with
+// a transient one on its last attempt), it stops reading rows and returns
+// that error once the batches already in flight have finished.
+func (t *Bulk) BulkInsertStream(ctx context.Context, query string, rows <-chan interface{}, opts StreamOptions) error {
+	if t.conn == nil {
+		return errors.New("DB Connection is nil")
+	}
+	if len(query) == 0 {
+		return errors.New("Query is empty")
+	}
+
+	maxConcurrency := opts.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+	maxBatchSize := opts.MaxBatchSize
+	if maxBatchSize <= 0 {
+		maxBatchSize = defaultMaxBatchSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, maxConcurrency)
+	errCh := make(chan error, 1)
+	var wg sync.WaitGroup
+	var totalProcessed int64
+
+	reportErr := func(err error) {
+		select {
+		case errCh <- err:
+			cancel()
+		default:
+		}
+	}
+
+	flush := func(batch []interface{}) {
+		if len(batch) == 0 {
+			return
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(batch []interface{}) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := func() (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("Panic while executing batch: %v", r)
+					}
+				}()
+				return t.execBatchWithRetry(ctx, query, batch, opts.RetryPolicy)
+			}()
+
+			total := atomic.AddInt64(&totalProcessed, int64(len(batch)))
+			if opts.OnProgress != nil {
+				opts.OnProgress(len(batch), int(total), err)
+			}
+			if err != nil {
+				reportErr(err)
+			}
+		}(batch)
+	}
+
+	ticker := time.NewTicker(defaultFlushInterval)
+	defer ticker.Stop()
+
+	var buffer []interface{}
+	var rowType reflect.Type
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ticker.C:
+			if len(buffer) > 0 && (opts.MinBatchSize <= 0 || len(buffer) >= opts.MinBatchSize) {
+				batch := buffer
+				buffer = nil
+				flush(batch)
+			}
+		case row, ok := <-rows:
+			if !ok {
+				break loop
+			}
+			if rowType == nil {
+				rowType = reflect.TypeOf(row)
+				if limit := maxBatchSizeForRow(row, t.dbType); limit < maxBatchSize {
+					maxBatchSize = limit
+				}
+			} else if reflect.TypeOf(row) != rowType {
+				reportErr(fmt.Errorf("Invalid type for row: expected %s, got %s", rowType, reflect.TypeOf(row)))
+				break loop
+			}
+			buffer = append(buffer, row)
+			if len(buffer) >= maxBatchSize {
+				batch := buffer
+				buffer = nil
+				flush(batch)
+			}
+		}
+	}
+
+	if len(buffer) > 0 {
+		flush(buffer)
+	}
+
+	wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+	}
+
+	return ctx.Err()
+}
+
+// maxBatchSizeForRow returns the dialect placeholder limit for row's struct type
+func maxBatchSizeForRow(row interface{}, dbType DatabaseType) int {
+	fCount := reflect.TypeOf(row).NumField()
+	if fCount == 0 {
+		return defaultMaxBatchSize
+	}
+
+	switch dbType {
+	case OracleDB:
+		return oracleMaxPlaceholders / fCount
+	case PostgresDB:
+		return postgresMaxPlaceholders / fCount
+	default:
+		return mysqlMaxPlaceholders / fCount
+	}
+}
+
+// execBatchWithRetry builds the dialect statement for batch and executes it,
+// retrying transient errors with exponential backoff according to policy
+func (t *Bulk) execBatchWithRetry(ctx context.Context, query string, batch []interface{}, policy RetryPolicy) error {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	var q string
+	var args []interface{}
+	var err error
+
+	switch t.dbType {
+	case OracleDB:
+		q, args, err = createStatementOracle(query, batch)
+	case PostgresDB:
+		q, args, err = createStatementPostgres(query, batch)
+	default:
+		q, args, err = createStatementMySQL(query, batch)
+	}
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		_, err = t.conn.ExecContext(ctx, q, args...)
+		if err == nil {
+			return nil
+		}
+		if !isRetryableError(err) || attempt == maxAttempts-1 {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoffDelay(attempt, policy)):
+		}
+	}
+
+	return err
+}
+
+// isRetryableError reports whether err looks like a transient deadlock,
+// lock wait, or connection failure worth retrying
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, marker := range retryableErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// backoffDelay computes the exponential backoff delay for attempt, bounded by policy
+func backoffDelay(attempt int, policy RetryPolicy) time.Duration {
+	base := policy.BaseDelay
+	if base <= 0 {
+		base = defaultBaseDelay
+	}
+	max := policy.MaxDelay
+	if max <= 0 {
+		max = defaultMaxDelay
+	}
+
+	delay := base * time.Duration(uint(1)<<uint(attempt))
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	return delay
+}
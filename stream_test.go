@@ -0,0 +1,187 @@
+package bulk
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeConn is a minimal driver.Conn that accepts any statement and reports
+// every Exec as one row affected, so BulkInsertStream's flush goroutine can
+// be exercised end to end without a real database
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return fakeResult{}, nil
+}
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("fakeStmt: queries not supported")
+}
+
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+func init() {
+	sql.Register("bulkfake", fakeDriver{})
+}
+
+func TestBulkInsertStreamBadSetup(t *testing.T) {
+	var conn *sql.DB
+	ctx := context.Background()
+
+	b, err := New(MySQLDB, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := make(chan interface{})
+	close(rows)
+
+	if err := b.BulkInsertStream(ctx, insertService, rows, StreamOptions{}); err == nil {
+		t.Error("expected error for nil connection")
+	}
+}
+
+func TestBulkInsertStreamEmptyQuery(t *testing.T) {
+	db, err := sql.Open("bulkfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	b, err := New(MySQLDB, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := make(chan interface{})
+	close(rows)
+
+	if err := b.BulkInsertStream(ctx, emptyQuery, rows, StreamOptions{}); err == nil {
+		t.Error("expected error for empty query")
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	if isRetryableError(nil) {
+		t.Error("nil error must not be retryable")
+	}
+	if !isRetryableError(errors.New("Error 1213: Deadlock found when trying to get lock")) {
+		t.Error("expected MySQL deadlock to be retryable")
+	}
+	if !isRetryableError(errors.New("ORA-00060: deadlock detected while waiting for resource")) {
+		t.Error("expected Oracle deadlock to be retryable")
+	}
+	if isRetryableError(errors.New("syntax error near SELECT")) {
+		t.Error("syntax errors must not be retryable")
+	}
+}
+
+func TestMaxBatchSizeForRow(t *testing.T) {
+	row := TestService{}
+
+	if limit := maxBatchSizeForRow(row, MySQLDB); limit != mysqlMaxPlaceholders/2 {
+		t.Errorf("expected MySQL limit %d, got %d", mysqlMaxPlaceholders/2, limit)
+	}
+	if limit := maxBatchSizeForRow(row, OracleDB); limit != oracleMaxPlaceholders/2 {
+		t.Errorf("expected Oracle limit %d, got %d", oracleMaxPlaceholders/2, limit)
+	}
+	if limit := maxBatchSizeForRow(row, PostgresDB); limit != postgresMaxPlaceholders/2 {
+		t.Errorf("expected Postgres limit %d, got %d", postgresMaxPlaceholders/2, limit)
+	}
+}
+
+// TestBulkInsertStreamRejectsMixedTypes ensures a stream fed rows of more
+// than one struct type reports a clean error instead of the unrecovered
+// "reflect: Field index out of range" panic that createStatementMySQL would
+// otherwise hit when building a batch from mismatched rows
+func TestBulkInsertStreamRejectsMixedTypes(t *testing.T) {
+	db, err := sql.Open("bulkfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	b, err := New(MySQLDB, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := make(chan interface{}, 2)
+	rows <- TestService{Description: "d", Tag: "t"}
+	rows <- losaStruktura{}
+	close(rows)
+
+	err = b.BulkInsertStream(ctx, insertService, rows, StreamOptions{})
+	if err == nil {
+		t.Fatal("expected error for mixed row types, got nil")
+	}
+}
+
+// TestBulkInsertStreamCancelPropagatesError ensures BulkInsertStream never
+// reports success (nil error) when the caller's context was canceled while a
+// row was still queued up, even though every batch it did flush succeeded
+func TestBulkInsertStreamCancelPropagatesError(t *testing.T) {
+	db, err := sql.Open("bulkfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	b, err := New(MySQLDB, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rows := make(chan interface{})
+
+	go func() {
+		rows <- TestService{Description: "d1", Tag: "t1"}
+		// give the periodic flush time to pick up the first row before we cancel
+		time.Sleep(300 * time.Millisecond)
+		cancel()
+		rows <- TestService{Description: "d2", Tag: "t2"}
+		close(rows)
+	}()
+
+	err = b.BulkInsertStream(ctx, insertService, rows, StreamOptions{MinBatchSize: 1})
+	if err == nil {
+		t.Error("expected cancellation error, got nil despite a row still queued when ctx was canceled")
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 100 * time.Millisecond}
+
+	if d := backoffDelay(0, policy); d != 10*time.Millisecond {
+		t.Errorf("expected 10ms, got %s", d)
+	}
+	if d := backoffDelay(10, policy); d != 100*time.Millisecond {
+		t.Errorf("expected backoff to cap at 100ms, got %s", d)
+	}
+}
@@ -0,0 +1,164 @@
+package bulk
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// columnBinding ties one query column to the struct field that supplies its
+// value, in the order the column appears in the query's column list
+type columnBinding struct {
+	fieldIndex int
+	omitEmpty  bool
+}
+
+// fieldSpec is the parsed form of a field's bulk/db struct tag
+type fieldSpec struct {
+	column    string
+	skip      bool
+	omitEmpty bool
+	pk        bool
+	auto      bool
+}
+
+// parseFieldTag reads the bulk tag (preferred) or db tag for f. A field with
+// neither tag returns a zero fieldSpec and ok=false
+func parseFieldTag(f reflect.StructField) (fieldSpec, bool, error) {
+	tag, ok := f.Tag.Lookup("bulk")
+	if !ok {
+		tag, ok = f.Tag.Lookup("db")
+	}
+	if !ok {
+		return fieldSpec{}, false, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	spec := fieldSpec{column: parts[0]}
+	if spec.column == "-" {
+		return fieldSpec{skip: true}, true, nil
+	}
+	if spec.column == "" {
+		return fieldSpec{}, true, fmt.Errorf("Field %s has an empty bulk/db tag name", f.Name)
+	}
+
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "omitempty":
+			spec.omitEmpty = true
+		case "pk":
+			spec.pk = true
+		case "auto":
+			spec.auto = true
+		}
+	}
+
+	return spec, true, nil
+}
+
+// structHasTags reports whether any field of t carries a bulk/db struct tag
+func structHasTags(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("bulk"); ok {
+			return true
+		}
+		if _, ok := t.Field(i).Tag.Lookup("db"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveColumnBindings matches columns to t's fields.
+//
+// When t has no bulk/db tags at all, fields are matched to columns by
+// declaration order, exactly like before tags were supported. Once any field
+// carries a tag, matching switches to by-name: every column must resolve to
+// a tagged field (bulk:"-", ",pk" and ",auto" fields are skipped and never
+// match a column), and every matchable tagged field must be used by some
+// column, or resolveColumnBindings returns an error.
+func resolveColumnBindings(t reflect.Type, columns []string) ([]columnBinding, error) {
+	if !structHasTags(t) {
+		if t.NumField() != len(columns) {
+			return nil, fmt.Errorf("Structure type doesn't match column count")
+		}
+		bindings := make([]columnBinding, len(columns))
+		for i := range columns {
+			bindings[i] = columnBinding{fieldIndex: i}
+		}
+		return bindings, nil
+	}
+
+	byColumn := make(map[string]columnBinding)
+	for i := 0; i < t.NumField(); i++ {
+		spec, tagged, err := parseFieldTag(t.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		if !tagged || spec.skip || spec.pk || spec.auto {
+			continue
+		}
+		byColumn[spec.column] = columnBinding{fieldIndex: i, omitEmpty: spec.omitEmpty}
+	}
+
+	bindings := make([]columnBinding, 0, len(columns))
+	for _, col := range columns {
+		b, ok := byColumn[col]
+		if !ok {
+			return nil, fmt.Errorf("Column %q has no matching struct field", col)
+		}
+		bindings = append(bindings, b)
+		delete(byColumn, col)
+	}
+
+	if len(byColumn) > 0 {
+		leftover := make([]string, 0, len(byColumn))
+		for col := range byColumn {
+			leftover = append(leftover, col)
+		}
+		return nil, fmt.Errorf("Struct field(s) for column(s) %s have no matching query column", strings.Join(leftover, ","))
+	}
+
+	return bindings, nil
+}
+
+// bindValue resolves the coerced driver value for binding b on row v,
+// substituting NULL for a zero value when the field has ",omitempty"
+func bindValue(v reflect.Value, b columnBinding) (interface{}, error) {
+	fv := v.Field(b.fieldIndex)
+	if b.omitEmpty && fv.IsZero() {
+		return nil, nil
+	}
+	return coerceValue(fv)
+}
+
+// coerceValue converts v into a form safe to hand to the SQL driver:
+// pointer-to-primitive fields are dereferenced (a nil pointer becomes NULL),
+// and anything implementing driver.Valuer (sql.Null*, and any custom type
+// such as an Oracle LOB wrapper) is resolved through its Value() method
+// instead of being handed to the driver as an opaque struct it can't bind.
+// time.Time and []byte are already valid driver values and pass through
+// untouched.
+func coerceValue(v reflect.Value) (interface{}, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		return coerceValue(v.Elem())
+	}
+
+	iface := v.Interface()
+
+	if valuer, ok := iface.(driver.Valuer); ok {
+		return valuer.Value()
+	}
+
+	switch iface.(type) {
+	case time.Time, []byte:
+		return iface, nil
+	}
+
+	return iface, nil
+}
@@ -0,0 +1,119 @@
+package bulk
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type taggedService struct {
+	ID          int       `bulk:"id,pk,auto"`
+	Description string    `bulk:"description"`
+	Tag         string    `db:"tag,omitempty"`
+	Internal    string    `bulk:"-"`
+	Created     time.Time `bulk:"created"`
+}
+
+const insertTaggedService = `insert into service (description, tag, created) values (?,?,?)`
+
+func TestResolveColumnBindingsByTag(t *testing.T) {
+	columns, err := extractQueryColumns(insertTaggedService)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bindings, err := resolveColumnBindings(reflect.TypeOf(taggedService{}), columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bindings) != 3 {
+		t.Fatalf("expected 3 bindings, got %d", len(bindings))
+	}
+	if !bindings[1].omitEmpty {
+		t.Error("expected tag column to carry omitempty")
+	}
+}
+
+func TestResolveColumnBindingsMissingColumn(t *testing.T) {
+	_, err := resolveColumnBindings(reflect.TypeOf(taggedService{}), []string{"description", "tag", "unknown"})
+	if err == nil {
+		t.Error("expected error for column with no matching field")
+	}
+}
+
+func TestResolveColumnBindingsUnmappedField(t *testing.T) {
+	_, err := resolveColumnBindings(reflect.TypeOf(taggedService{}), []string{"description"})
+	if err == nil {
+		t.Error("expected error for tagged field left unmapped")
+	}
+}
+
+func TestResolveColumnBindingsPositionalFallback(t *testing.T) {
+	bindings, err := resolveColumnBindings(reflect.TypeOf(TestService{}), []string{"description", "tag"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bindings[0].fieldIndex != 0 || bindings[1].fieldIndex != 1 {
+		t.Error("expected positional fallback to match by declaration order")
+	}
+}
+
+func TestCoerceValueResolvesDriverValuer(t *testing.T) {
+	valid, err := coerceValue(reflect.ValueOf(sql.NullString{String: "hi", Valid: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid != "hi" {
+		t.Errorf("expected sql.NullString{Valid:true} to coerce to the underlying string, got %v (%T)", valid, valid)
+	}
+
+	invalid, err := coerceValue(reflect.ValueOf(sql.NullString{Valid: false}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invalid != nil {
+		t.Errorf("expected sql.NullString{Valid:false} to coerce to nil, got %v", invalid)
+	}
+}
+
+func TestCoerceValuePassesThroughTimeAndBytes(t *testing.T) {
+	now := time.Unix(0, 0)
+	v, err := coerceValue(reflect.ValueOf(now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.(time.Time) != now {
+		t.Errorf("expected time.Time to pass through untouched, got %v", v)
+	}
+
+	b, err := coerceValue(reflect.ValueOf([]byte("raw")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b.([]byte)) != "raw" {
+		t.Errorf("expected []byte to pass through untouched, got %v", b)
+	}
+}
+
+func TestCreateStatementMySQLWithTags(t *testing.T) {
+	rows := []interface{}{
+		taggedService{ID: 1, Description: "Desc 1", Tag: "", Created: time.Unix(0, 0)},
+		taggedService{ID: 2, Description: "Desc 2", Tag: "Tag 2", Created: time.Unix(0, 0)},
+	}
+
+	statement, args, err := createStatementMySQL(insertTaggedService, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 6 {
+		t.Fatalf("expected 6 args, got %d", len(args))
+	}
+	if args[1] != nil {
+		t.Errorf("expected empty Tag with omitempty to bind NULL, got %v", args[1])
+	}
+	expected := "insert into service (description, tag, created)  values (?,?,?),(?,?,?)"
+	if statement != expected {
+		t.Errorf("expected %q, got %q", expected, statement)
+	}
+}
@@ -0,0 +1,204 @@
+package bulk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind classifies a single token produced by tokenizeSQL
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenPunct
+	tokenString
+	tokenEOF
+)
+
+// token is a single lexical unit of a query
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenizeSQL splits query into a stream of tokens, skipping whitespace and
+// "--" / "/* */" comments. Backtick/double-quoted runs are returned as a
+// single identifier token (so MySQL backticks and ANSI/Oracle double quotes
+// never leak parens or commas into the structural scan), and single-quoted
+// string literals (with '' escaping) are returned as a single string token.
+func tokenizeSQL(query string) ([]token, error) {
+	var tokens []token
+	n := len(query)
+
+	for i := 0; i < n; {
+		c := query[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '-' && i+1 < n && query[i+1] == '-':
+			for i < n && query[i] != '\n' {
+				i++
+			}
+
+		case c == '/' && i+1 < n && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("Unterminated comment in query: %s", query)
+			}
+			i += end + 4
+
+		case c == '`' || c == '"':
+			quote := c
+			j := i + 1
+			for j < n && query[j] != quote {
+				j++
+			}
+			if j >= n {
+				return nil, fmt.Errorf("Unterminated quoted identifier in query: %s", query)
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: query[i+1 : j]})
+			i = j + 1
+
+		case c == '\'':
+			j := i + 1
+			for j < n {
+				if query[j] == '\'' {
+					if j+1 < n && query[j+1] == '\'' {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			if j >= n {
+				return nil, fmt.Errorf("Unterminated string literal in query: %s", query)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: query[i : j+1]})
+			i = j + 1
+
+		case c == '(' || c == ')' || c == ',' || c == ';':
+			tokens = append(tokens, token{kind: tokenPunct, text: string(c)})
+			i++
+
+		default:
+			j := i
+			for j < n && !isTokenBoundary(query[j]) {
+				j++
+			}
+			if j == i {
+				i++ // unrecognized byte, skip it defensively rather than looping forever
+				continue
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: query[i:j]})
+			i = j
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF})
+	return tokens, nil
+}
+
+// isTokenBoundary reports whether c ends whatever bare identifier/keyword
+// is being scanned
+func isTokenBoundary(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '(', ')', ',', ';', '\'', '`', '"':
+		return true
+	default:
+		return false
+	}
+}
+
+// parseInsert locates "INSERT INTO <table> (<col>, ...)" in query using
+// tokenizeSQL, and returns the table name and column list. It rejects
+// INSERT ... SELECT up front with a clear error instead of producing a
+// malformed statement, and rejects anything else it can't confidently parse
+// (unclosed parens/quotes, a nested paren in the column list, stray "?").
+func parseInsert(query string) (string, []string, error) {
+	tokens, err := tokenizeSQL(query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pos := 0
+	next := func() token {
+		tok := tokens[pos]
+		if pos < len(tokens)-1 {
+			pos++
+		}
+		return tok
+	}
+	peek := func() token { return tokens[pos] }
+
+	insertTok := next()
+	if insertTok.kind != tokenIdent || !strings.EqualFold(insertTok.text, "insert") {
+		return "", nil, fmt.Errorf("Query does not start with INSERT. Query: %s", query)
+	}
+
+	intoTok := next()
+	if intoTok.kind != tokenIdent || !strings.EqualFold(intoTok.text, "into") {
+		return "", nil, fmt.Errorf("Query is missing INTO. Query: %s", query)
+	}
+
+	tableTok := next()
+	if tableTok.kind != tokenIdent {
+		return "", nil, fmt.Errorf("Query table not properly defined. Query: %s", query)
+	}
+	tableParts := []string{tableTok.text}
+	for peek().kind == tokenIdent && peek().text == "." {
+		next() // consume "."
+		partTok := next()
+		if partTok.kind != tokenIdent {
+			return "", nil, fmt.Errorf("Query table not properly defined. Query: %s", query)
+		}
+		tableParts = append(tableParts, partTok.text)
+	}
+	table := strings.Join(tableParts, ".")
+
+	if tok := peek(); tok.kind == tokenIdent && strings.EqualFold(tok.text, "select") {
+		return "", nil, fmt.Errorf("INSERT ... SELECT is not supported. Query: %s", query)
+	}
+
+	openParen := next()
+	if openParen.kind != tokenPunct || openParen.text != "(" {
+		return "", nil, fmt.Errorf("Query columns not properly defined. Query: %s", query)
+	}
+
+	var columns []string
+	for {
+		tok := next()
+		switch {
+		case tok.kind == tokenEOF:
+			return "", nil, fmt.Errorf("Query columns not properly defined. Query: %s", query)
+
+		case tok.kind == tokenPunct && tok.text == ")":
+			if len(columns) == 0 {
+				return "", nil, fmt.Errorf("Query columns not properly defined. Query: %s", query)
+			}
+			if tok := peek(); tok.kind == tokenIdent && strings.EqualFold(tok.text, "select") {
+				return "", nil, fmt.Errorf("INSERT ... SELECT is not supported. Query: %s", query)
+			}
+			return table, columns, nil
+
+		case tok.kind == tokenPunct && tok.text == ",":
+			continue
+
+		case tok.kind == tokenPunct:
+			// a nested paren (or any other punctuation) inside the column
+			// list means this isn't a plain column list, e.g. a subquery
+			return "", nil, fmt.Errorf("Query columns not properly defined. Query: %s", query)
+
+		default:
+			if tok.text == "?" {
+				return "", nil, fmt.Errorf("Invalid column name: %s", tok.text)
+			}
+			if strings.EqualFold(tok.text, "select") {
+				return "", nil, fmt.Errorf("INSERT ... SELECT is not supported. Query: %s", query)
+			}
+			columns = append(columns, tok.text)
+		}
+	}
+}
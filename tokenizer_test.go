@@ -0,0 +1,81 @@
+package bulk
+
+import "testing"
+
+func TestParseInsertBasic(t *testing.T) {
+	table, columns, err := parseInsert(`insert into service (description, tag) values (?,?)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table != "service" {
+		t.Errorf("expected table %q, got %q", "service", table)
+	}
+	if len(columns) != 2 || columns[0] != "description" || columns[1] != "tag" {
+		t.Errorf("unexpected columns: %v", columns)
+	}
+}
+
+func TestParseInsertSchemaQualified(t *testing.T) {
+	table, columns, err := parseInsert(`insert into myschema.service (description, tag) values (?,?)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table != "myschema.service" {
+		t.Errorf("expected table %q, got %q", "myschema.service", table)
+	}
+	if len(columns) != 2 {
+		t.Errorf("unexpected columns: %v", columns)
+	}
+}
+
+func TestParseInsertQuotedIdentifiers(t *testing.T) {
+	table, columns, err := parseInsert("insert into `my schema`.`service` (`description, with comma`, `tag`) values (?,?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table != "my schema.service" {
+		t.Errorf("expected table %q, got %q", "my schema.service", table)
+	}
+	if len(columns) != 2 || columns[0] != "description, with comma" {
+		t.Errorf("unexpected columns: %v", columns)
+	}
+}
+
+func TestParseInsertWithComments(t *testing.T) {
+	query := "insert into service -- trailing comment\n(description, tag) /* inline */ values (?,?)"
+	table, columns, err := parseInsert(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table != "service" || len(columns) != 2 {
+		t.Errorf("expected service(description,tag), got %q %v", table, columns)
+	}
+}
+
+func TestParseInsertRejectsSelect(t *testing.T) {
+	queries := []string{
+		`insert into service select description, tag from other`,
+		`insert into service (id, (select something from somewhere where id = 'somethingelse'), tag)`,
+		`insert into service (description, tag) select description, tag from other`,
+	}
+	for _, q := range queries {
+		if _, _, err := parseInsert(q); err == nil {
+			t.Errorf("expected error for query: %s", q)
+		}
+	}
+}
+
+func TestParseInsertRejectsMalformed(t *testing.T) {
+	queries := []string{
+		``,
+		`insert into service`,
+		`insert into service values (?, ?)`,
+		`insert into service (description, tag`,
+		`insert into service (?, ?)`,
+	}
+	for _, q := range queries {
+		if _, _, err := parseInsert(q); err == nil {
+			t.Errorf("expected error for query: %s", q)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ConflictSpec describes how BulkUpsert should resolve a primary/unique key
+// conflict for the rows being inserted.
+//
+// KeyColumns identifies the columns that make up the conflicting key and is
+// always required. UpdateColumns lists the columns to refresh when a
+// conflict occurs; if left empty, every query column except KeyColumns is
+// updated. IgnoreConflict, when true, discards conflicting rows instead of
+// updating them (MySQL INSERT IGNORE / Oracle MERGE without WHEN MATCHED /
+// Postgres ON CONFLICT DO NOTHING).
+type ConflictSpec struct {
+	KeyColumns     []string
+	UpdateColumns  []string
+	IgnoreConflict bool
+}
+
+// BulkUpsert will transform query into a bulk insert variation that resolves
+// key conflicts according to conflict, and execute the created queries in a
+// transaction.
+//
+// for MySQL, conflicting rows are merged with
+//  ON DUPLICATE KEY UPDATE col=VALUES(col), ...
+// for Oracle, the statement becomes a
+//  MERGE INTO ... USING (...) src ON (...) WHEN MATCHED THEN UPDATE ... WHEN NOT MATCHED THEN INSERT ...
+// for Postgres, conflicting rows are merged with
+//  ON CONFLICT (key columns) DO UPDATE SET col=EXCLUDED.col, ...
+// rows must be structures of same type, otherwise checker function will return an error
+func (t *Bulk) BulkUpsert(ctx context.Context, query string, rows []interface{}, conflict ConflictSpec) error {
+	err := t.checkInsertRequest(query, rows)
+	if err != nil {
+		return err
+	}
+	if len(conflict.KeyColumns) == 0 {
+		return errors.New("Conflict key columns not defined")
+	}
+
+	chunks := chunkIt(rows, t.dbType)
+	insertData := make([]queryArgs, len(chunks))
+
+	switch t.dbType {
+	case OracleDB:
+		for i := range chunks {
+			insertData[i].query, insertData[i].args, err = createUpsertStatementOracle(query, chunks[i], conflict)
+			if err != nil {
+				return err
+			}
+		}
+	case PostgresDB:
+		for i := range chunks {
+			insertData[i].query, insertData[i].args, err = createUpsertStatementPostgres(query, chunks[i], conflict)
+			if err != nil {
+				return err
+			}
+		}
+	default: // MySQLDB
+		for i := range chunks {
+			insertData[i].query, insertData[i].args, err = createUpsertStatementMySQL(query, chunks[i], conflict)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return t.execInsertData(insertData)
+}
+
+// validateConflictColumns reports an error if conflict.KeyColumns or an
+// explicit conflict.UpdateColumns references a column that isn't part of
+// columns, instead of letting it reach the database as an opaque
+// syntax/unknown-column error
+func validateConflictColumns(conflict ConflictSpec, columns []string) error {
+	known := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		known[c] = true
+	}
+
+	for _, col := range conflict.KeyColumns {
+		if !known[col] {
+			return fmt.Errorf("Conflict key column %q is not part of the query's column list", col)
+		}
+	}
+	for _, col := range conflict.UpdateColumns {
+		if !known[col] {
+			return fmt.Errorf("Conflict update column %q is not part of the query's column list", col)
+		}
+	}
+
+	return nil
+}
+
+// createUpsertStatementMySQL builds on top of createStatementMySQL, either
+// switching the statement to INSERT IGNORE, or appending an
+// ON DUPLICATE KEY UPDATE clause for the resolved update columns
+func createUpsertStatementMySQL(query string, rows []interface{}, conflict ConflictSpec) (string, []interface{}, error) {
+	columns, err := extractQueryColumns(query)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := validateConflictColumns(conflict, columns); err != nil {
+		return "", nil, err
+	}
+
+	statement, args, err := createStatementMySQL(query, rows)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if conflict.IgnoreConflict {
+		statement, err = insertIgnoreMySQL(statement)
+		if err != nil {
+			return "", nil, err
+		}
+		return statement, args, nil
+	}
+
+	updateColumns := conflict.UpdateColumns
+	if len(updateColumns) == 0 {
+		updateColumns = subtractColumns(columns, conflict.KeyColumns)
+	}
+	if len(updateColumns) == 0 {
+		return "", nil, errors.New("No columns left to update on conflict")
+	}
+
+	assignments := make([]string, len(updateColumns))
+	for i, col := range updateColumns {
+		assignments[i] = fmt.Sprintf("%s=VALUES(%s)", col, col)
+	}
+
+	statement = fmt.Sprintf("%s ON DUPLICATE KEY UPDATE %s", statement, strings.Join(assignments, ","))
+	return statement, args, nil
+}
+
+// insertIgnoreMySQL inserts the IGNORE keyword right after the leading
+// INSERT keyword of statement, matching it case-insensitively (same as the
+// tokenizer does for the INSERT/INTO keywords) instead of a literal
+// lowercase substring replace, which would silently do nothing for a
+// caller-supplied "INSERT INTO ..." query
+func insertIgnoreMySQL(statement string) (string, error) {
+	trimmed := strings.TrimLeft(statement, " \t\r\n")
+	prefixLen := len(statement) - len(trimmed)
+
+	const keyword = "insert"
+	if len(trimmed) < len(keyword) || !strings.EqualFold(trimmed[:len(keyword)], keyword) {
+		return "", fmt.Errorf("Query does not start with INSERT. Query: %s", statement)
+	}
+
+	return statement[:prefixLen] + "insert ignore" + trimmed[len(keyword):], nil
+}
+
+// createUpsertStatementPostgres builds on top of createStatementPostgres,
+// appending an ON CONFLICT clause that either does nothing or updates the
+// resolved update columns from the proposed row (EXCLUDED)
+func createUpsertStatementPostgres(query string, rows []interface{}, conflict ConflictSpec) (string, []interface{}, error) {
+	columns, err := extractQueryColumns(query)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := validateConflictColumns(conflict, columns); err != nil {
+		return "", nil, err
+	}
+
+	statement, args, err := createStatementPostgres(query, rows)
+	if err != nil {
+		return "", nil, err
+	}
+
+	conflictTarget := fmt.Sprintf("(%s)", strings.Join(conflict.KeyColumns, ","))
+
+	if conflict.IgnoreConflict {
+		statement = fmt.Sprintf("%s ON CONFLICT %s DO NOTHING", statement, conflictTarget)
+		return statement, args, nil
+	}
+
+	updateColumns := conflict.UpdateColumns
+	if len(updateColumns) == 0 {
+		updateColumns = subtractColumns(columns, conflict.KeyColumns)
+	}
+	if len(updateColumns) == 0 {
+		return "", nil, errors.New("No columns left to update on conflict")
+	}
+
+	assignments := make([]string, len(updateColumns))
+	for i, col := range updateColumns {
+		assignments[i] = fmt.Sprintf("%s=EXCLUDED.%s", col, col)
+	}
+
+	statement = fmt.Sprintf("%s ON CONFLICT %s DO UPDATE SET %s", statement, conflictTarget, strings.Join(assignments, ","))
+	return statement, args, nil
+}
+
+// createUpsertStatementOracle builds a MERGE INTO statement for rows, using a
+// UNION ALL of DUAL selects aliased to the query column names as the MERGE
+// source, since MERGE needs named source columns rather than positional binds
+func createUpsertStatementOracle(query string, rows []interface{}, conflict ConflictSpec) (string, []interface{}, error) {
+	columns, err := extractQueryColumns(query)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := validateConflictColumns(conflict, columns); err != nil {
+		return "", nil, err
+	}
+	table, err := extractTableName(query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	updateColumns := conflict.UpdateColumns
+	if len(updateColumns) == 0 && !conflict.IgnoreConflict {
+		updateColumns = subtractColumns(columns, conflict.KeyColumns)
+	}
+
+	bindings, err := resolveColumnBindings(reflect.TypeOf(rows[0]), columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	lenCols := len(columns)
+	lenRows := reflect.ValueOf(rows).Len()
+
+	args := make([]interface{}, 0, lenRows*lenCols)
+	sourceRows := make([]string, lenRows)
+
+	var argCount int
+	for i := range rows {
+		v := reflect.ValueOf(rows[i])
+		aliased := make([]string, lenCols)
+		for y, col := range columns {
+			argCount++
+			aliased[y] = fmt.Sprintf(":%d %s", argCount, col)
+			val, err := bindValue(v, bindings[y])
+			if err != nil {
+				return "", nil, err
+			}
+			args = append(args, val)
+		}
+		sourceRows[i] = fmt.Sprintf("select %s from dual", strings.Join(aliased, ","))
+	}
+
+	onClause := make([]string, len(conflict.KeyColumns))
+	for i, col := range conflict.KeyColumns {
+		onClause[i] = fmt.Sprintf("dst.%s = src.%s", col, col)
+	}
+
+	insertVals := make([]string, lenCols)
+	for i, col := range columns {
+		insertVals[i] = fmt.Sprintf("src.%s", col)
+	}
+
+	statement := fmt.Sprintf("merge into %s dst using (%s) src on (%s)",
+		table, strings.Join(sourceRows, " union all "), strings.Join(onClause, " and "))
+
+	if !conflict.IgnoreConflict && len(updateColumns) > 0 {
+		updateSet := make([]string, len(updateColumns))
+		for i, col := range updateColumns {
+			updateSet[i] = fmt.Sprintf("dst.%s = src.%s", col, col)
+		}
+		statement = fmt.Sprintf("%s when matched then update set %s", statement, strings.Join(updateSet, ","))
+	}
+
+	statement = fmt.Sprintf("%s when not matched then insert (%s) values (%s)",
+		statement, strings.Join(columns, ","), strings.Join(insertVals, ","))
+	statement = removeDoubleSpace(statement)
+
+	return statement, args, nil
+}
+
+// extractTableName returns the table name of an INSERT INTO query, parsed
+// with the same tokenizer that backs extractQueryColumns
+func extractTableName(query string) (string, error) {
+	table, _, err := parseInsert(query)
+	if err != nil {
+		return "", err
+	}
+	return table, nil
+}
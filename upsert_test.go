@@ -0,0 +1,164 @@
+package bulk
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+func TestBulkUpsertMySQL(t *testing.T) {
+	var conn *sql.DB
+	ctx := context.Background()
+
+	b, err := New(MySQLDB, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []interface{}{
+		TestService{Description: "Desc 1", Tag: "Tag 1"},
+		TestService{Description: "Desc 2", Tag: "Tag 2"},
+	}
+
+	err = b.BulkUpsert(ctx, insertService, rows, ConflictSpec{})
+	if err == nil {
+		t.Error("expected error for missing key columns")
+	}
+
+	err = b.BulkUpsert(ctx, insertService, rows, ConflictSpec{KeyColumns: []string{"description"}})
+	fmt.Println("Expected error: ", err)
+	if err == nil {
+		t.Error("expected error") // DB connection is nil
+	}
+
+	statement, args, err := createUpsertStatementMySQL(insertService, rows, ConflictSpec{KeyColumns: []string{"description"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 4 {
+		t.Errorf("expected 4 args, got %d", len(args))
+	}
+	expected := "insert into service (description, tag)  values (?,?),(?,?) ON DUPLICATE KEY UPDATE tag=VALUES(tag)"
+	if statement != expected {
+		t.Errorf("expected %q, got %q", expected, statement)
+	}
+
+	statement, _, err = createUpsertStatementMySQL(insertService, rows, ConflictSpec{KeyColumns: []string{"description"}, IgnoreConflict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "insert ignore into service (description, tag)  values (?,?),(?,?)"
+	if statement != expected {
+		t.Errorf("expected %q, got %q", expected, statement)
+	}
+}
+
+func TestCreateUpsertStatementMySQLIgnoreConflictIsCaseInsensitive(t *testing.T) {
+	rows := []interface{}{TestService{Description: "Desc 1", Tag: "Tag 1"}}
+
+	statement, _, err := createUpsertStatementMySQL(
+		`INSERT INTO service (description, tag) values (?,?)`,
+		rows,
+		ConflictSpec{KeyColumns: []string{"description"}, IgnoreConflict: true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "insert ignore INTO service (description, tag)  values (?,?)"
+	if statement != expected {
+		t.Errorf("expected %q, got %q", expected, statement)
+	}
+}
+
+func TestCreateUpsertStatementMySQLRejectsUnknownConflictColumns(t *testing.T) {
+	rows := []interface{}{TestService{Description: "Desc 1", Tag: "Tag 1"}}
+
+	if _, _, err := createUpsertStatementMySQL(insertService, rows, ConflictSpec{KeyColumns: []string{"unknown"}}); err == nil {
+		t.Error("expected error for key column not in the query's column list")
+	}
+	if _, _, err := createUpsertStatementMySQL(insertService, rows, ConflictSpec{KeyColumns: []string{"description"}, UpdateColumns: []string{"unknown"}}); err == nil {
+		t.Error("expected error for update column not in the query's column list")
+	}
+}
+
+func TestCreateUpsertStatementOracleRejectsUnknownConflictColumns(t *testing.T) {
+	rows := []interface{}{TestService{Description: "Desc 1", Tag: "Tag 1"}}
+
+	if _, _, err := createUpsertStatementOracle(insertService, rows, ConflictSpec{KeyColumns: []string{"unknown"}}); err == nil {
+		t.Error("expected error for key column not in the query's column list")
+	}
+	if _, _, err := createUpsertStatementOracle(insertService, rows, ConflictSpec{KeyColumns: []string{"description"}, UpdateColumns: []string{"unknown"}}); err == nil {
+		t.Error("expected error for update column not in the query's column list")
+	}
+}
+
+func TestCreateUpsertStatementPostgresRejectsUnknownConflictColumns(t *testing.T) {
+	rows := []interface{}{TestService{Description: "Desc 1", Tag: "Tag 1"}}
+
+	if _, _, err := createUpsertStatementPostgres(insertService, rows, ConflictSpec{KeyColumns: []string{"unknown"}}); err == nil {
+		t.Error("expected error for key column not in the query's column list")
+	}
+	if _, _, err := createUpsertStatementPostgres(insertService, rows, ConflictSpec{KeyColumns: []string{"description"}, UpdateColumns: []string{"unknown"}}); err == nil {
+		t.Error("expected error for update column not in the query's column list")
+	}
+}
+
+func TestBulkUpsertPostgres(t *testing.T) {
+	var conn *sql.DB
+	ctx := context.Background()
+
+	b, err := New(PostgresDB, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []interface{}{
+		TestService{Description: "Desc 1", Tag: "Tag 1"},
+		TestService{Description: "Desc 2", Tag: "Tag 2"},
+	}
+
+	err = b.BulkUpsert(ctx, insertService, rows, ConflictSpec{KeyColumns: []string{"description"}})
+	if err == nil {
+		t.Error("expected error") // DB connection is nil
+	}
+
+	statement, args, err := createUpsertStatementPostgres(insertService, rows, ConflictSpec{KeyColumns: []string{"description"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 4 {
+		t.Errorf("expected 4 args, got %d", len(args))
+	}
+	expected := "insert into service (description, tag)  values ($1,$2),($3,$4) ON CONFLICT (description) DO UPDATE SET tag=EXCLUDED.tag"
+	if statement != expected {
+		t.Errorf("expected %q, got %q", expected, statement)
+	}
+
+	statement, _, err = createUpsertStatementPostgres(insertService, rows, ConflictSpec{KeyColumns: []string{"description"}, IgnoreConflict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "insert into service (description, tag)  values ($1,$2),($3,$4) ON CONFLICT (description) DO NOTHING"
+	if statement != expected {
+		t.Errorf("expected %q, got %q", expected, statement)
+	}
+}
+
+func TestBulkUpsertOracle(t *testing.T) {
+	rows := []interface{}{
+		TestService{Description: "Desc 1", Tag: "Tag 1"},
+		TestService{Description: "Desc 2", Tag: "Tag 2"},
+	}
+
+	statement, args, err := createUpsertStatementOracle(insertService, rows, ConflictSpec{KeyColumns: []string{"description"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 4 {
+		t.Errorf("expected 4 args, got %d", len(args))
+	}
+	if want := "merge into service dst"; statement[:len(want)] != want {
+		t.Errorf("expected statement to start with %q, got %q", want, statement)
+	}
+}